@@ -13,6 +13,7 @@ package board
 
 import (
 	"fmt"
+	"math/bits"
 )
 
 type Bitboard uint64
@@ -56,6 +57,15 @@ func squareIndex(f, r int) int {
 	return (r << 3) + f
 }
 
+// Index returns the square index of the lowest set bit.
+func (b *Bitboard) Index() int {
+	return bits.TrailingZeros64(uint64(*b))
+}
+
+func popCount(b Bitboard) int {
+	return bits.OnesCount64(uint64(b))
+}
+
 func IndexToBitBoard(i int) Bitboard {
 	b := Bitboard(0)
 	b.SetBit(i)
@@ -45,3 +45,10 @@ func TestBitboardToSlice(t *testing.T) {
 		})
 	}
 }
+
+func TestBitboardIndex(t *testing.T) {
+	for i := 0; i < 64; i++ {
+		b := IndexToBitBoard(i)
+		assert.Equal(t, i, b.Index())
+	}
+}
@@ -1,6 +1,10 @@
 package board
 
 import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -139,6 +143,174 @@ func TestEnPassant(t *testing.T) {
 	}
 }
 
+func TestValidateFEN(t *testing.T) {
+	tests := []struct {
+		name string
+		fen  string
+		err  string // empty for a valid fen, otherwise part of the expected error
+	}{
+		{"initial position", InitialPosition, ""},
+		{"en passant", "rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3", ""},
+		{"no castling", "4k3/8/8/8/8/8/8/4K3 b - - 12 40", ""},
+		{"missing field", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0", "expected 6 fields"},
+		{"seven ranks", "rnbqkbnr/pppppppp/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "expected 8 ranks"},
+		{"short rank", "rnbqkbnr/ppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "has 7 squares"},
+		{"long rank", "rnbqkbnr/pppppppp/p8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "has 9 squares"},
+		{"digit out of range", "rnbqkbnr/pppppppp/9/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "bad character"},
+		{"bad piece", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNX w KQkq - 0 1", "bad piece"},
+		{"bad side", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR x KQkq - 0 1", "bad side to move"},
+		{"bad castling", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQxq - 0 1", "bad castling field"},
+		{"bad en passant", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq e4 0 1", "bad en passant square"},
+		{"bad halfmove", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - x 1", "bad halfmove clock"},
+		{"bad fullmove", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 0", "bad fullmove number"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateFEN(tc.fen)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+// TestRandomPlacementFEN round-trips random FENs through ValidateFEN, MustFEN and ToFEN.
+// The placements are not legal chess positions: piece counts are unbounded and
+// either king may be in check. Only pawns on the back ranks and touching kings are excluded.
+func TestRandomPlacementFEN(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	pieces := []rune("PNBRQpnbrq")
+	for i := 0; i < 1000; i++ {
+		var cb coloredBoard
+		whiteKing := rng.Intn(64)
+		blackKing := rng.Intn(64)
+		for kingDistance(whiteKing, blackKing) < 2 {
+			blackKing = rng.Intn(64)
+		}
+		for sq := range cb {
+			cb[sq] = noPiece
+			if rng.Intn(3) != 0 {
+				continue
+			}
+			cp := rune2Piece[pieces[rng.Intn(len(pieces))]]
+			if cp.piece == Pawn && (sq>>3 == Rank1 || sq>>3 == Rank8) {
+				continue
+			}
+			cb[sq] = cp
+		}
+		cb[whiteKing] = rune2Piece['K']
+		cb[blackKing] = rune2Piece['k']
+
+		expected := createPosition(cb)
+		expected.WhiteMove = rng.Intn(2) == 0
+		side := "b"
+		if expected.WhiteMove {
+			side = "w"
+		}
+
+		castling := ""
+		for bit, ch := range "KQkq" {
+			if rng.Intn(2) == 0 {
+				expected.CastleSide |= 1 << bit
+				castling += string(ch)
+			}
+		}
+		if castling == "" {
+			castling = "-"
+		}
+
+		ep := "-"
+		if rng.Intn(2) == 0 {
+			file, rank := rng.Intn(8), Rank3
+			if expected.WhiteMove {
+				rank = Rank6
+			}
+			expected.EnPassant = IndexToBitBoard(squareIndex(file, rank))
+			ep = string(rune('a'+file)) + strconv.Itoa(rank+1)
+		}
+
+		expected.HalfMoveClock = uint8(rng.Intn(256))
+		expected.FullMoveNumber = uint16(1 + rng.Intn(65535))
+
+		fen := fmt.Sprintf("%s %s %s %s %d %d", piecePlacement(cb), side, castling, ep,
+			expected.HalfMoveClock, expected.FullMoveNumber)
+		assert.NoError(t, ValidateFEN(fen), fen)
+		position := MustFEN(t, fen)
+		assert.Equal(t, expected, position, fen)
+		assert.Equal(t, fen, position.ToFEN())
+	}
+}
+
+func kingDistance(a, b int) int {
+	return max(abs(a&7-b&7), abs(a>>3-b>>3))
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func piecePlacement(cb coloredBoard) string {
+	var ranks []string
+	for r := Rank8; r >= Rank1; r-- {
+		rank, empty := "", 0
+		for f := FileA; f <= FileH; f++ {
+			cp := cb[squareIndex(f, r)]
+			if cp == noPiece {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				rank += strconv.Itoa(empty)
+				empty = 0
+			}
+			for ch, p := range rune2Piece {
+				if p == cp {
+					rank += string(ch)
+				}
+			}
+		}
+		if empty > 0 {
+			rank += strconv.Itoa(empty)
+		}
+		ranks = append(ranks, rank)
+	}
+	return strings.Join(ranks, "/")
+}
+
+func TestToFEN(t *testing.T) {
+	tests := []struct {
+		name string
+		fen  string
+	}{
+		{"initial position", InitialPosition},
+		{"kiwipete", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1"},
+		{"white en passant", "rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3"},
+		{"black en passant", "rnbqkbnr/pppp1ppp/8/8/3Pp3/8/PPP1PPPP/RNBQKBNR b KQkq d3 0 2"},
+		{"partial castling", "r3k2r/8/8/8/8/8/8/R3K2R b Kq - 7 42"},
+		{"no castling", "8/8/4k3/8/8/3RK3/8/8 w - - 99 120"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.fen, MustFEN(t, tc.fen).ToFEN())
+		})
+	}
+}
+
+func TestToFEN_ZeroFullMoveNumber(t *testing.T) {
+	for _, position := range []Position{{}, createPosition(createColoredBoard("4k3/8/8/8/8/8/8/4K3"))} {
+		fen := position.ToFEN()
+		assert.NoError(t, ValidateFEN(fen), fen)
+		assert.True(t, strings.HasSuffix(fen, " 0 1"), fen)
+	}
+}
+
 func TestBB(t *testing.T) {
 	BB()
 }
@@ -1,6 +1,8 @@
 package board
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"slices"
 	"strconv"
@@ -12,6 +14,8 @@ var fileNumber = map[string]int{
 	"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "h": 8,
 }
 
+const pieceLetters = " PNBRQK"
+
 var rune2Piece = map[rune]coloredPiece{
 	'P': {Pawn, ColorWhite},
 	'N': {Knight, ColorWhite},
@@ -28,10 +32,35 @@ var rune2Piece = map[rune]coloredPiece{
 }
 
 func CreatePositionFormFEN(fen string) Position {
-	fields := strings.Split(fen, " ")
-	if len(fields) != 6 {
-		log.Fatal("bad fen")
+	position, err := ParseFEN(fen)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return position
+}
+
+// TestingT is the part of testing.TB that MustFEN needs.
+type TestingT interface {
+	Helper()
+	Fatalf(format string, args ...any)
+}
+
+// MustFEN parses fen and fails the test immediately if it is malformed.
+func MustFEN(t TestingT, fen string) Position {
+	t.Helper()
+	position, err := ParseFEN(fen)
+	if err != nil {
+		t.Fatalf("invalid fen %q: %v", fen, err)
 	}
+	return position
+}
+
+// ParseFEN validates fen and builds the position, reporting malformed input as an error.
+func ParseFEN(fen string) (Position, error) {
+	if err := ValidateFEN(fen); err != nil {
+		return Position{}, err
+	}
+	fields := strings.Split(fen, " ")
 	coloredBoard := createColoredBoard(fields[0])
 	position := createPosition(coloredBoard)
 
@@ -41,10 +70,158 @@ func CreatePositionFormFEN(fen string) Position {
 
 	halfMoveClock, _ := strconv.Atoi(fields[4])
 	position.HalfMoveClock = uint8(halfMoveClock)
+	fullMoveNumber, _ := strconv.Atoi(fields[5])
+	position.FullMoveNumber = uint16(fullMoveNumber)
 
-	// todo: do we need Fullmove counter?
+	return position, nil
+}
 
-	return position
+// ToFEN returns the six-field FEN of the position.
+func (position Position) ToFEN() string {
+	var sb strings.Builder
+	for r := Rank8; r >= Rank1; r-- {
+		empty := 0
+		for f := FileA; f <= FileH; f++ {
+			ch := position.pieceRune(squareIndex(f, r))
+			if ch == 0 {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				sb.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			sb.WriteRune(ch)
+		}
+		if empty > 0 {
+			sb.WriteString(strconv.Itoa(empty))
+		}
+		if r != Rank1 {
+			sb.WriteByte('/')
+		}
+	}
+
+	side := "b"
+	if position.WhiteMove {
+		side = "w"
+	}
+	return fmt.Sprintf("%s %s %s %s %d %d", sb.String(), side, castleString(position.CastleSide),
+		enPassantString(position.EnPassant), position.HalfMoveClock, max(position.FullMoveNumber, 1))
+}
+
+// pieceRune returns the FEN letter of the piece on the square, or 0 if it is empty.
+func (position Position) pieceRune(sq int) rune {
+	for _, pc := range []Piece{Pawn, Knight, Bishop, Rook, Queen, King} {
+		if !position.GetPiece(pc).IsBitSet(sq) {
+			continue
+		}
+		ch := rune(pieceLetters[pc])
+		if position.Black.IsBitSet(sq) {
+			ch = unicode.ToLower(ch)
+		}
+		return ch
+	}
+	return 0
+}
+
+func castleString(castle uint8) string {
+	if castle == 0 {
+		return "-"
+	}
+	s := ""
+	for i, ch := range "KQkq" {
+		if castle&(1<<i) != 0 {
+			s += string(ch)
+		}
+	}
+	return s
+}
+
+func enPassantString(ep Bitboard) string {
+	if ep == 0 {
+		return "-"
+	}
+	sq := ep.Index()
+	return string(rune('a'+sq&7)) + strconv.Itoa(sq>>3+1)
+}
+
+// ValidateFEN checks the syntax of all six FEN fields without building a position.
+func ValidateFEN(fen string) error {
+	fields := strings.Split(fen, " ")
+	if len(fields) != 6 {
+		return fmt.Errorf("expected 6 fields, got %d", len(fields))
+	}
+	if err := validatePiecePlacement(fields[0]); err != nil {
+		return err
+	}
+	if fields[1] != "w" && fields[1] != "b" {
+		return fmt.Errorf("bad side to move %q", fields[1])
+	}
+	if err := validateCastling(fields[2]); err != nil {
+		return err
+	}
+	if err := validateEnPassant(fields[3]); err != nil {
+		return err
+	}
+	if n, err := strconv.Atoi(fields[4]); err != nil || n < 0 || n > 255 {
+		return fmt.Errorf("bad halfmove clock %q", fields[4])
+	}
+	if n, err := strconv.Atoi(fields[5]); err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("bad fullmove number %q", fields[5])
+	}
+	return nil
+}
+
+func validatePiecePlacement(piecePlacement string) error {
+	ranks := strings.Split(piecePlacement, "/")
+	if len(ranks) != 8 {
+		return fmt.Errorf("expected 8 ranks, got %d", len(ranks))
+	}
+	for _, rank := range ranks {
+		squares := 0
+		for _, char := range rank {
+			switch {
+			case char >= '1' && char <= '8':
+				squares += int(char - '0')
+			case unicode.IsLetter(char):
+				if _, ok := rune2Piece[char]; !ok {
+					return fmt.Errorf("bad piece %q", char)
+				}
+				squares++
+			default:
+				return fmt.Errorf("bad character %q in rank %q", char, rank)
+			}
+		}
+		if squares != 8 {
+			return fmt.Errorf("rank %q has %d squares", rank, squares)
+		}
+	}
+	return nil
+}
+
+func validateCastling(c string) error {
+	if c == "-" {
+		return nil
+	}
+	if c == "" {
+		return errors.New("empty castling field")
+	}
+	for _, ch := range c {
+		if !strings.ContainsRune("KQkq", ch) {
+			return fmt.Errorf("bad castling field %q", c)
+		}
+	}
+	return nil
+}
+
+func validateEnPassant(s string) error {
+	if s == "-" {
+		return nil
+	}
+	if len(s) != 2 || s[0] < 'a' || s[0] > 'h' || (s[1] != '3' && s[1] != '6') {
+		return fmt.Errorf("bad en passant square %q", s)
+	}
+	return nil
 }
 
 func enPassant(s string) Bitboard {
@@ -4,10 +4,11 @@ import (
 	"log"
 )
 
-type SquareMoves map[Bitboard][]Bitboard
-type Generics map[Piece]SquareMoves
-type SliderSquareMoves map[Bitboard][][]Bitboard
-type Sliders map[Piece]SliderSquareMoves
+// SquareMoves and SliderSquareMoves are indexed by square (0-63).
+type SquareMoves [][]Bitboard
+type Generics [PieceNB]SquareMoves
+type SliderSquareMoves [][][]Bitboard
+type Sliders [PieceNB]SliderSquareMoves
 
 const (
 	FileA int = iota
@@ -44,6 +45,7 @@ const (
 	Rook
 	Queen
 	King
+	PieceNB
 
 	ColorWhite Color = 0
 	ColorBlack Color = 1
@@ -62,6 +64,7 @@ type Position struct {
 	CastleSide                                    uint8
 	EnPassant                                     Bitboard
 	HalfMoveClock                                 uint8
+	FullMoveNumber                                uint16
 }
 
 type coloredPiece struct {
@@ -71,6 +74,12 @@ type coloredPiece struct {
 
 type coloredBoard [64]coloredPiece
 
+const (
+	fileABB      Bitboard = 0x0101010101010101
+	fileHBB      Bitboard = fileABB << 7
+	lightSquares Bitboard = 0x55aa55aa55aa55aa
+)
+
 const InitialPosition = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
 
 var noPiece = coloredPiece{Empty, 255}
@@ -134,16 +143,19 @@ func (position Position) ToFlat() Bitboard {
 	return toFlat(position.Bishops, position.Knights, position.Rooks, position.Queens, position.Kings, position.Pawns)
 }
 
+// AllSliders returns the positions reachable by the sliding pieces pc of the side to move.
+// The order is deterministic: pieces by ascending square, then directions and
+// squares in the order stored in sliders.
 func (position Position) AllSliders(sliders Sliders, pc Piece) []Position {
 	var positions []Position
 	color := position.filterColor()           // take only the color to move
 	piecesInColorToMove := color.GetPiece(pc) // get the pieces of that color
-	if *piecesInColorToMove == 0 {
+	if *piecesInColorToMove == 0 || len(sliders[pc]) == 0 {
 		return nil
 	}
 	allFlat := position.ToFlat() // get all the pieces on the board flattened to bitboard
 	for _, bitBoard := range piecesInColorToMove.ToSlice() {
-		directions := sliders[pc][bitBoard]
+		directions := sliders[pc][bitBoard.Index()]
 		for _, direction := range directions {
 			for _, move := range direction {
 				if allFlat&move == move { // if there is a piece in the way, stop
@@ -162,16 +174,18 @@ func (position Position) AllSliders(sliders Sliders, pc Piece) []Position {
 	return positions
 }
 
+// AllGenerics returns the positions reachable by the pieces pc of the side to move,
+// in the same deterministic order as AllSliders.
 func (position Position) AllGenerics(generics Generics, pc Piece) []Position {
 	var positions []Position
 	color := position.filterColor()           // take only the color to move
 	piecesInColorToMove := color.GetPiece(pc) // get the pieces of that color
-	if *piecesInColorToMove == 0 {
+	if *piecesInColorToMove == 0 || len(generics[pc]) == 0 {
 		return nil
 	}
 	allFlat := position.ToFlat() // get all the pieces on the board flattened to bitboard
 	for _, bitBoard := range piecesInColorToMove.ToSlice() {
-		moves := generics[pc][bitBoard]
+		moves := generics[pc][bitBoard.Index()]
 		for _, move := range moves {
 			if allFlat&move == move { // if there is a piece in the way, stop
 				break
@@ -186,6 +200,130 @@ func (position Position) AllGenerics(generics Generics, pc Piece) []Position {
 	return positions
 }
 
+// AttacksBy returns every square attacked by color, squares of its own pieces included.
+// Pieces without a move table, as in a zero Sliders or Generics, add no attacks.
+func (position Position) AttacksBy(sliders Sliders, generics Generics, color Color) Bitboard {
+	own := position.White
+	if color == ColorBlack {
+		own = position.Black
+	}
+	allFlat := position.White | position.Black
+
+	var attacks Bitboard
+	pawns := position.Pawns & own
+	if color == ColorWhite {
+		attacks = (pawns&^fileABB)<<7 | (pawns&^fileHBB)<<9
+	} else {
+		attacks = (pawns&^fileHBB)>>7 | (pawns&^fileABB)>>9
+	}
+	attacks |= genericAttacks(generics[Knight], position.Knights&own)
+	attacks |= genericAttacks(generics[King], position.Kings&own)
+	attacks |= sliderAttacks(sliders[Bishop], position.Bishops&own, allFlat)
+	attacks |= sliderAttacks(sliders[Rook], position.Rooks&own, allFlat)
+	attacks |= sliderAttacks(sliders[Queen], position.Queens&own, allFlat)
+	return attacks
+}
+
+func genericAttacks(squareMoves SquareMoves, pieces Bitboard) Bitboard {
+	var attacks Bitboard
+	if len(squareMoves) == 0 {
+		return attacks
+	}
+	for ; pieces != 0; pieces &= pieces - 1 {
+		for _, move := range squareMoves[pieces.Index()] {
+			attacks |= move
+		}
+	}
+	return attacks
+}
+
+func sliderAttacks(squareMoves SliderSquareMoves, pieces, allFlat Bitboard) Bitboard {
+	var attacks Bitboard
+	if len(squareMoves) == 0 {
+		return attacks
+	}
+	for ; pieces != 0; pieces &= pieces - 1 {
+		for _, direction := range squareMoves[pieces.Index()] {
+			for _, move := range direction {
+				attacks |= move
+				if allFlat&move != 0 { // the first piece in the way is attacked, the rest is shadowed
+					break
+				}
+			}
+		}
+	}
+	return attacks
+}
+
+// PinnedPieces returns the pieces of color that are absolutely pinned to their own king.
+// The rook and bishop tables give the pin rays; without them no pins are found.
+func (position Position) PinnedPieces(sliders Sliders, color Color) Bitboard {
+	own, enemy := position.White, position.Black
+	if color == ColorBlack {
+		own, enemy = enemy, own
+	}
+	king := position.Kings & own
+	if king == 0 {
+		return 0
+	}
+	sq := king.Index()
+	allFlat := position.White | position.Black
+	pinned := pinsAlong(sliders[Rook], sq, own, (position.Rooks|position.Queens)&enemy, allFlat)
+	pinned |= pinsAlong(sliders[Bishop], sq, own, (position.Bishops|position.Queens)&enemy, allFlat)
+	return pinned
+}
+
+// pinsAlong walks the rays outwards from the king and marks an own piece as pinned
+// when the next piece behind it is one of the pinners.
+func pinsAlong(squareMoves SliderSquareMoves, sq int, own, pinners, allFlat Bitboard) Bitboard {
+	var pinned Bitboard
+	if len(squareMoves) == 0 {
+		return pinned
+	}
+	for _, direction := range squareMoves[sq] {
+		var candidate Bitboard
+		for _, move := range direction {
+			if allFlat&move == 0 {
+				continue
+			}
+			if candidate == 0 {
+				if own&move == 0 {
+					break
+				}
+				candidate = move
+				continue
+			}
+			if pinners&move != 0 {
+				pinned |= candidate
+			}
+			break
+		}
+	}
+	return pinned
+}
+
+// IsFiftyMoveDraw reports whether 100 half moves have passed without a pawn move or capture.
+// A checkmate delivered on the last move takes precedence; that is left to the caller.
+func (position Position) IsFiftyMoveDraw() bool {
+	return position.HalfMoveClock >= 100
+}
+
+// IsInsufficientMaterial reports whether neither side can deliver mate:
+// bare kings, a single minor piece, or only bishops all on one square color.
+func (position Position) IsInsufficientMaterial() bool {
+	if position.Pawns|position.Rooks|position.Queens != 0 {
+		return false
+	}
+	if popCount(position.Knights|position.Bishops) <= 1 {
+		return true
+	}
+	if position.Knights != 0 {
+		return false
+	}
+	light := position.Bishops & lightSquares
+	return light == 0 || light == position.Bishops
+}
+
 func (position *Position) GetPiece(piece Piece) *Bitboard {
 	switch piece {
 	case Pawn:
@@ -28,3 +28,50 @@ func TestPosition_filterColor(t *testing.T) {
 		})
 	}
 }
+
+func TestPosition_IsFiftyMoveDraw(t *testing.T) {
+	tests := []struct {
+		name     string
+		board    string
+		expected bool
+	}{
+		{"initial position", InitialPosition, false},
+		{"one half move short", "8/8/4k3/8/8/3RK3/8/8 w - - 99 120", false},
+		{"exactly fifty moves", "8/8/4k3/8/8/3RK3/8/8 b - - 100 120", true},
+		{"past fifty moves", "8/8/4k3/8/8/3RK3/8/8 b - - 130 135", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MustFEN(t, tc.board).IsFiftyMoveDraw())
+		})
+	}
+}
+
+func TestPosition_IsInsufficientMaterial(t *testing.T) {
+	tests := []struct {
+		name     string
+		board    string
+		expected bool
+	}{
+		{"K vs K", "8/8/4k3/8/8/4K3/8/8 w - - 0 1", true},
+		{"KN vs K", "8/8/4k3/8/8/4K3/8/6N1 w - - 0 1", true},
+		{"KB vs K", "8/8/4k3/8/8/4K3/8/5B2 w - - 0 1", true},
+		{"K vs KB", "8/8/4k3/2b5/8/4K3/8/8 w - - 0 1", true},
+		{"KB vs KB same color", "8/8/4k3/3b4/8/4K3/8/5B2 w - - 0 1", true},
+		{"KB vs KB opposite color", "8/8/4k3/2b5/8/4K3/8/5B2 w - - 0 1", false},
+		{"KBB vs K opposite color", "8/8/4k3/8/8/4K3/8/2B2B2 w - - 0 1", false},
+		{"KNN vs K", "8/8/4k3/8/8/4K3/8/1N4N1 w - - 0 1", false},
+		{"KN vs KB", "8/8/4k3/2b5/8/4K3/8/6N1 w - - 0 1", false},
+		{"KP vs K", "8/8/4k3/8/8/4K3/4P3/8 w - - 0 1", false},
+		{"KR vs K", "8/8/4k3/8/8/4K3/8/7R w - - 0 1", false},
+		{"KQ vs K", "8/8/4k3/8/8/4K3/8/3Q4 w - - 0 1", false},
+		{"initial position", InitialPosition, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MustFEN(t, tc.board).IsInsufficientMaterial())
+		})
+	}
+}
@@ -41,3 +41,106 @@ func TestPosition_AllSliders(t *testing.T) {
 		})
 	}
 }
+
+func TestPosition_GenerationOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		board    string
+		expected []board.Bitboard
+	}{
+		// black to move, f8 along one diagonal: squares in ray order e7, d6, c5, b4, a3
+		{"ray order, black", "rnbqkbnr/pppp1ppp/4p3/8/8/3P4/PPP1PPPP/RNBQKBNR b KQkq - 0 1",
+			[]board.Bitboard{0x0410000000000024, 0x0400080000000024, 0x0400000400000024, 0x0400000002000024, 0x0400000000010024}},
+		// c1-d2 comes before f1-e2: pieces by ascending square
+		{"piece order", "rnbqkbnr/pppp1ppp/4p3/8/8/3PP3/PPP2PPP/RNBQKBNR w KQkq - 0 1",
+			[]board.Bitboard{0x2400000000000820, 0x2400000000001004}},
+	}
+
+	sliders, _ := generator.NewGenerator()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			position := board.MustFEN(t, tc.board)
+			var bishops []board.Bitboard
+			for _, pos := range position.AllSliders(sliders, board.Bishop) {
+				bishops = append(bishops, pos.Bishops)
+			}
+			assert.Equal(t, tc.expected, bishops)
+		})
+	}
+}
+
+func TestPosition_AttacksBy(t *testing.T) {
+	const kiwipete = "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1"
+	tests := []struct {
+		name     string
+		board    string
+		color    board.Color
+		expected board.Bitboard
+	}{
+		{"initial position, white", board.InitialPosition, board.ColorWhite, 0xffff7e},
+		{"initial position, black", board.InitialPosition, board.ColorBlack, 0x7effff0000000000},
+		{"rook, knight and pawn, white", "4k3/8/8/8/3N4/8/4P3/R3K3 w - - 0 1", board.ColorWhite, 0x01011523012b3d3e},
+		{"rook, knight and pawn, black", "4k3/8/8/8/3N4/8/4P3/R3K3 w - - 0 1", board.ColorBlack, 0x2838000000000000},
+		{"kiwipete, white", kiwipete, board.ColorWhite, 0x0028f5ea75fff97e},
+		{"kiwipete, black", kiwipete, board.ColorBlack, 0xffbbfeaed78d5000},
+	}
+
+	sliders, generics := generator.NewGenerator()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			position := board.MustFEN(t, tc.board)
+			assert.Equal(t, tc.expected, position.AttacksBy(sliders, generics, tc.color))
+			allocs := testing.AllocsPerRun(10, func() { position.AttacksBy(sliders, generics, tc.color) })
+			assert.Zero(t, allocs)
+		})
+	}
+}
+
+func TestPosition_AttacksByWithoutTables(t *testing.T) {
+	position := board.MustFEN(t, board.InitialPosition)
+	// only the pawns attack when there are no piece tables
+	assert.Equal(t, board.Bitboard(0xff0000), position.AttacksBy(board.Sliders{}, board.Generics{}, board.ColorWhite))
+	assert.Equal(t, board.Bitboard(0xff0000000000), position.AttacksBy(board.Sliders{}, board.Generics{}, board.ColorBlack))
+}
+
+func TestPosition_PinnedPieces(t *testing.T) {
+	tests := []struct {
+		name     string
+		board    string
+		color    board.Color
+		expected board.Bitboard
+	}{
+		{"initial position", board.InitialPosition, board.ColorWhite, 0},
+		{"bishop pinned on a file", "k3r3/8/8/8/8/8/4B3/4K3 w - - 0 1", board.ColorWhite, 0x1000},
+		{"knight pinned on a diagonal", "k7/8/8/8/7b/8/5N2/4K3 w - - 0 1", board.ColorWhite, 0x2000},
+		{"knight off the rook's line", "k7/8/8/8/7r/8/5N2/4K3 w - - 0 1", board.ColorWhite, 0},
+		{"bishop does not pin along a file", "k3b3/8/8/8/8/8/4B3/4K3 w - - 0 1", board.ColorWhite, 0},
+		{"two own pieces in between", "k3r3/8/8/8/4N3/8/4B3/4K3 w - - 0 1", board.ColorWhite, 0},
+		{"enemy piece in between", "k3r3/8/8/8/4n3/8/4B3/4K3 w - - 0 1", board.ColorWhite, 0},
+		{"rook and queen pin two black pieces", "4k3/4np2/8/7Q/4R3/8/8/K7 b - - 0 1", board.ColorBlack, 0x30000000000000},
+	}
+
+	sliders, _ := generator.NewGenerator()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			position := board.MustFEN(t, tc.board)
+			assert.Equal(t, tc.expected, position.PinnedPieces(sliders, tc.color))
+		})
+	}
+}
+
+func TestPosition_PieceWithoutTable(t *testing.T) {
+	position := board.MustFEN(t, board.InitialPosition)
+	sliders, generics := generator.NewGenerator()
+	for _, pc := range []board.Piece{board.Pawn, board.Knight, board.King} {
+		assert.Nil(t, position.AllSliders(sliders, pc))
+	}
+	for _, pc := range []board.Piece{board.Pawn, board.Bishop, board.Rook, board.Queen} {
+		assert.Nil(t, position.AllGenerics(generics, pc))
+	}
+}
+
+func TestPosition_PinnedPiecesWithoutTables(t *testing.T) {
+	position := board.MustFEN(t, "k3r3/8/8/8/8/8/4B3/4K3 w - - 0 1")
+	assert.Zero(t, position.PinnedPieces(board.Sliders{}, board.ColorWhite))
+}
@@ -5,7 +5,7 @@ import (
 )
 
 func generateBishopMoves() board.SliderSquareMoves {
-	var squareMoves = make(board.SliderSquareMoves)
+	var squareMoves = make(board.SliderSquareMoves, 64)
 	for pos := 0; pos < 64; pos++ {
 		var directions [][]board.Bitboard
 		moves := bishopSE(pos)
@@ -25,7 +25,7 @@ func generateBishopMoves() board.SliderSquareMoves {
 			directions = append(directions, moves)
 		}
 
-		squareMoves[board.IndexToBitBoard(pos)] = exactSize(directions)
+		squareMoves[pos] = exactSize(directions)
 	}
 	return squareMoves
 }
@@ -5,12 +5,12 @@ import (
 )
 
 func NewGenerator() (board.Sliders, board.Generics) {
-	sliders := make(board.Sliders)
+	var sliders board.Sliders
 	sliders[board.Rook] = generateRookMoves()
 	sliders[board.Bishop] = generateBishopMoves()
 	sliders[board.Queen] = generateQueenMoves(sliders[board.Rook], sliders[board.Bishop])
 
-	generic := make(board.Generics)
+	var generic board.Generics
 	generic[board.King] = kingMoves()
 	generic[board.Knight] = knightMoves()
 
@@ -18,13 +18,12 @@ func NewGenerator() (board.Sliders, board.Generics) {
 }
 
 func generateQueenMoves(rookMoves, bishopMoves board.SliderSquareMoves) board.SliderSquareMoves {
-	var squareMoves = make(board.SliderSquareMoves)
+	var squareMoves = make(board.SliderSquareMoves, 64)
 	for pos := 0; pos < 64; pos++ {
 		var directions [][]board.Bitboard
-		bbIndex := board.IndexToBitBoard(pos)
-		directions = append(directions, rookMoves[bbIndex]...)
-		directions = append(directions, bishopMoves[bbIndex]...)
-		squareMoves[bbIndex] = exactSize(directions)
+		directions = append(directions, rookMoves[pos]...)
+		directions = append(directions, bishopMoves[pos]...)
+		squareMoves[pos] = exactSize(directions)
 	}
 	return squareMoves
 }
@@ -36,7 +36,7 @@ func knightMoves() board.SquareMoves {
 	return generateGenericMoves(knightSteps)
 }
 func generateGenericMoves(steps steps) board.SquareMoves {
-	knightMoves := make(board.SquareMoves)
+	knightMoves := make(board.SquareMoves, 64)
 
 	for pos := 0; pos < 64; pos++ {
 		var list []board.Bitboard
@@ -52,7 +52,7 @@ func generateGenericMoves(steps steps) board.SquareMoves {
 			}
 		}
 		if len(list) > 0 {
-			knightMoves[board.IndexToBitBoard(pos)] = exactSize(list)
+			knightMoves[pos] = exactSize(list)
 		}
 	}
 	return knightMoves
@@ -26,7 +26,7 @@ func TestGenericMoves(t *testing.T) {
 	for _, test := range tests {
 		t.Run("Moves", func(t *testing.T) {
 			squares := test.fun()
-			moves := squares[board.IndexToBitBoard(test.pos)]
+			moves := squares[test.pos]
 			assert.Equal(t, len(test.moves), len(moves))
 			for _, i := range test.moves {
 				assert.Contains(t, moves, i)
@@ -2,13 +2,26 @@ package uci
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"strings"
+
+	"chess/board"
 )
 
+// session holds the state kept between commands, such as the position a "go" will search.
+type session struct {
+	position board.Position
+}
+
+func newSession() *session {
+	return &session{position: board.CreatePositionFormFEN(board.InitialPosition)}
+}
+
 func Start() {
+	s := newSession()
 	f, err := os.Create("data.txt")
 	if err != nil {
 		log.Fatal(err)
@@ -23,11 +36,16 @@ func Start() {
 		if err != nil {
 			log.Fatal(err)
 		}
-		switch command {
+		name, args, _ := strings.Cut(command, " ")
+		switch name {
 		case "uci":
 			respond(f, "id name MyChessEngine")
 			respond(f, "id author MyName")
 			respond(f, "uciok")
+		case "position":
+			if err := s.setPosition(strings.Fields(args)); err != nil {
+				respond(f, fmt.Sprintf("info string %s", err))
+			}
 		}
 		_, err := f.WriteString("\n")
 		if err != nil {
@@ -39,6 +57,51 @@ func Start() {
 	}
 }
 
+// setPosition applies a "position" command; on error the current position is kept.
+func (s *session) setPosition(args []string) error {
+	position, err := parsePosition(args)
+	if err != nil {
+		return err
+	}
+	s.position = position
+	return nil
+}
+
+// parsePosition handles the arguments of "position startpos|fen <fen> [moves ...]".
+// The "moves" token and its list are optional.
+func parsePosition(args []string) (board.Position, error) {
+	if len(args) == 0 {
+		return board.Position{}, errors.New("position: missing startpos or fen")
+	}
+	var fen string
+	var rest []string
+	switch args[0] {
+	case "startpos":
+		fen = board.InitialPosition
+		rest = args[1:]
+	case "fen":
+		i := 1
+		for i < len(args) && args[i] != "moves" {
+			i++
+		}
+		fen = strings.Join(args[1:i], " ")
+		rest = args[i:]
+	default:
+		return board.Position{}, fmt.Errorf("position: unknown type %q", args[0])
+	}
+	position, err := board.ParseFEN(fen)
+	if err != nil {
+		return board.Position{}, fmt.Errorf("position: %w", err)
+	}
+	if len(rest) > 0 && rest[0] != "moves" {
+		return board.Position{}, fmt.Errorf("position: unexpected token %q", rest[0])
+	}
+	if len(rest) > 1 {
+		return board.Position{}, errors.New("position: applying moves is not supported yet")
+	}
+	return position, nil
+}
+
 func respond(f *os.File, response string) {
 	fmt.Printf("%s\n", response)
 	logUCI(f, response)
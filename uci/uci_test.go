@@ -0,0 +1,67 @@
+package uci
+
+import (
+	"strings"
+	"testing"
+
+	"chess/board"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePosition(t *testing.T) {
+	const fen = "rnbqkbnr/pppp1ppp/4p3/8/8/3P4/PPP1PPPP/RNBQKBNR w KQkq - 0 1"
+	tests := []struct {
+		name     string
+		command  string
+		expected string
+	}{
+		{"startpos", "startpos", board.InitialPosition},
+		{"startpos empty moves", "startpos moves", board.InitialPosition},
+		{"fen", "fen " + fen, fen},
+		{"fen empty moves", "fen " + fen + " moves", fen},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			position, err := parsePosition(strings.Fields(tc.command))
+			assert.NoError(t, err)
+			assert.Equal(t, board.MustFEN(t, tc.expected), position)
+		})
+	}
+}
+
+func TestParsePositionErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+	}{
+		{"empty", ""},
+		{"unknown type", "somewhere"},
+		{"malformed fen", "fen 8/8/8 w - - 0 1"},
+		{"fen without fields", "fen"},
+		{"garbage after startpos", "startpos e2e4"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parsePosition(strings.Fields(tc.command))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSessionSetPosition(t *testing.T) {
+	const fen = "4k3/8/8/8/4p3/8/4P3/4K3 b - - 3 40"
+	s := newSession()
+	assert.Equal(t, board.MustFEN(t, board.InitialPosition), s.position)
+
+	assert.NoError(t, s.setPosition(strings.Fields("fen "+fen)))
+	assert.Equal(t, board.MustFEN(t, fen), s.position)
+
+	assert.Error(t, s.setPosition(strings.Fields("fen 8/8/8 w - - 0 1")))
+	assert.Equal(t, board.MustFEN(t, fen), s.position, "a malformed command keeps the position")
+
+	assert.NoError(t, s.setPosition([]string{"startpos"}))
+	assert.Equal(t, board.MustFEN(t, board.InitialPosition), s.position)
+}